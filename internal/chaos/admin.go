@@ -0,0 +1,56 @@
+package chaos
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AdminHandler serves POST /chaos: the request body is a JSON Profile
+// that becomes the new active profile, replacing whatever was active
+// before (including a profile picked up from a named scenario).
+func AdminHandler(ctrl *Controller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var p Profile
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			http.Error(w, "invalid chaos profile: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctrl.SetActive(&p)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p)
+	})
+}
+
+// ScenarioHandler serves POST /chaos/scenarios/{name}: it activates the
+// named scenario from the config file's chaos.scenarios map.
+func ScenarioHandler(ctrl *Controller) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/chaos/scenarios/")
+		if name == "" {
+			http.Error(w, "missing scenario name", http.StatusBadRequest)
+			return
+		}
+
+		p, ok := ctrl.Scenario(name)
+		if !ok {
+			http.Error(w, "unknown chaos scenario: "+name, http.StatusNotFound)
+			return
+		}
+
+		ctrl.SetActive(&p)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p)
+	})
+}
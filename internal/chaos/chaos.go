@@ -0,0 +1,222 @@
+// Package chaos provides a fault-injection middleware so the PADT mock
+// server can stand in for an unreliable upstream during client
+// resiliency testing: latency, 5xx errors, connection resets, truncated
+// responses and slow-drip writes, all switchable at runtime.
+package chaos
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile describes one fault-injection configuration. A zero-value field
+// disables that particular fault.
+type Profile struct {
+	Name                string  `json:"name,omitempty" yaml:"name,omitempty"`
+	LatencyMeanMs       int     `json:"latencyMeanMs" yaml:"latencyMeanMs"`
+	LatencyStdDevMs     int     `json:"latencyStdDevMs" yaml:"latencyStdDevMs"`
+	ErrorRate           float64 `json:"errorRate" yaml:"errorRate"`
+	ResetRate           float64 `json:"resetRate" yaml:"resetRate"`
+	TruncateRate        float64 `json:"truncateRate" yaml:"truncateRate"`
+	SlowDripBytesPerSec int     `json:"slowDripBytesPerSec" yaml:"slowDripBytesPerSec"`
+}
+
+// latency returns the delay to apply for one request. With no std-dev
+// configured the delay is fixed at LatencyMeanMs; otherwise it is drawn
+// from a normal distribution centred on LatencyMeanMs and floored at 0.
+func (p Profile) latency() time.Duration {
+	if p.LatencyStdDevMs <= 0 {
+		return time.Duration(p.LatencyMeanMs) * time.Millisecond
+	}
+	ms := rand.NormFloat64()*float64(p.LatencyStdDevMs) + float64(p.LatencyMeanMs)
+	if ms < 0 {
+		ms = 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Config is the "chaos:" section of the server's YAML config file.
+type Config struct {
+	Enabled   bool               `yaml:"enabled"`
+	Default   Profile            `yaml:"default"`
+	Scenarios map[string]Profile `yaml:"scenarios"`
+}
+
+type fileConfig struct {
+	Chaos Config `yaml:"chaos"`
+}
+
+// LoadConfig extracts the chaos config from a full server config file.
+func LoadConfig(src []byte) (Config, error) {
+	var fc fileConfig
+	if err := yaml.Unmarshal(src, &fc); err != nil {
+		return Config{}, fmt.Errorf("parsing chaos config: %w", err)
+	}
+	return fc.Chaos, nil
+}
+
+// Controller holds the currently active fault profile (nil means chaos is
+// off) and the named scenarios it can be switched to at runtime via the
+// admin endpoints.
+type Controller struct {
+	active    atomic.Value // *Profile
+	scenarios map[string]Profile
+}
+
+// NewController builds a Controller from a parsed Config, activating the
+// default profile immediately if cfg.Enabled.
+func NewController(cfg Config) *Controller {
+	c := &Controller{scenarios: cfg.Scenarios}
+	if cfg.Enabled {
+		p := cfg.Default
+		c.active.Store(&p)
+	} else {
+		c.active.Store((*Profile)(nil))
+	}
+	return c
+}
+
+// Active returns the currently active profile, or nil if chaos is off.
+func (c *Controller) Active() *Profile {
+	v := c.active.Load()
+	if v == nil {
+		return nil
+	}
+	return v.(*Profile)
+}
+
+// SetActive installs p as the active profile. Passing nil disables chaos.
+func (c *Controller) SetActive(p *Profile) {
+	c.active.Store(p)
+}
+
+// Scenario looks up a named scenario from the config.
+func (c *Controller) Scenario(name string) (Profile, bool) {
+	p, ok := c.scenarios[name]
+	return p, ok
+}
+
+// Middleware applies the Controller's active profile to every request:
+// latency, then a chance of an injected 5xx or connection reset, and
+// (via the wrapped ResponseWriter) a chance of truncated or slow-drip
+// writes for whatever the next handler sends back.
+func Middleware(ctrl *Controller) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			profile := ctrl.Active()
+			if profile == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if d := profile.latency(); d > 0 {
+				time.Sleep(d)
+			}
+
+			if profile.ErrorRate > 0 && rand.Float64() < profile.ErrorRate {
+				http.Error(w, "chaos: injected failure", http.StatusServiceUnavailable)
+				return
+			}
+
+			if profile.ResetRate > 0 && rand.Float64() < profile.ResetRate {
+				resetConnection(w)
+				return
+			}
+
+			next.ServeHTTP(&chaosWriter{ResponseWriter: w, profile: profile}, r)
+		})
+	}
+}
+
+// resetConnection hijacks the connection and closes it with SO_LINGER 0
+// so the client observes a reset rather than a clean FIN.
+func resetConnection(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.SetLinger(0)
+	}
+	conn.Close()
+}
+
+// chaosWriter wraps the real ResponseWriter to apply truncation and
+// slow-drip faults to whatever bytes the handler writes.
+type chaosWriter struct {
+	http.ResponseWriter
+	profile      *Profile
+	decided      bool
+	willTruncate bool
+}
+
+func (cw *chaosWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		cw.decided = true
+		cw.willTruncate = cw.profile.TruncateRate > 0 && rand.Float64() < cw.profile.TruncateRate
+	}
+
+	data := p
+	if cw.willTruncate {
+		data = p[:len(p)/2]
+	}
+
+	var (
+		n   int
+		err error
+	)
+	if cw.profile.SlowDripBytesPerSec > 0 {
+		n, err = cw.writeSlow(data)
+	} else {
+		n, err = cw.ResponseWriter.Write(data)
+	}
+
+	if cw.willTruncate {
+		if hj, ok := cw.ResponseWriter.(http.Hijacker); ok {
+			if conn, _, herr := hj.Hijack(); herr == nil {
+				conn.Close()
+			}
+		}
+		return len(p), nil
+	}
+	return n, err
+}
+
+// writeSlow drips data out at roughly SlowDripBytesPerSec, flushing after
+// each chunk so partial writes actually reach the client over time.
+func (cw *chaosWriter) writeSlow(data []byte) (int, error) {
+	chunkSize := cw.profile.SlowDripBytesPerSec / 10
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	flusher, _ := cw.ResponseWriter.(http.Flusher)
+
+	written := 0
+	for written < len(data) {
+		end := written + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		n, err := cw.ResponseWriter.Write(data[written:end])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return written, nil
+}
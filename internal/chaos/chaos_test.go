@@ -0,0 +1,80 @@
+package chaos
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+// recorder is a minimal http.ResponseWriter (deliberately not a Hijacker)
+// so tests can observe exactly what chaosWriter sends downstream.
+type recorder struct {
+	header http.Header
+	buf    bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{header: make(http.Header)}
+}
+
+func (r *recorder) Header() http.Header         { return r.header }
+func (r *recorder) WriteHeader(statusCode int)  {}
+func (r *recorder) Write(p []byte) (int, error) { return r.buf.Write(p) }
+
+func TestChaosWriterTruncateReportsFullLengthButWritesHalf(t *testing.T) {
+	rec := newRecorder()
+	cw := &chaosWriter{ResponseWriter: rec, profile: &Profile{TruncateRate: 1}}
+
+	data := []byte("0123456789")
+	n, err := cw.Write(data)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if n != len(data) {
+		t.Fatalf("Write returned n = %d, want %d (caller believes the full body was sent)", n, len(data))
+	}
+	if got := rec.buf.String(); got != string(data[:len(data)/2]) {
+		t.Fatalf("downstream got %q, want only the first half %q", got, data[:len(data)/2])
+	}
+}
+
+func TestChaosWriterNoFaultWritesThrough(t *testing.T) {
+	rec := newRecorder()
+	cw := &chaosWriter{ResponseWriter: rec, profile: &Profile{}}
+
+	data := []byte("unmodified")
+	n, err := cw.Write(data)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if n != len(data) {
+		t.Fatalf("Write returned n = %d, want %d", n, len(data))
+	}
+	if got := rec.buf.String(); got != string(data) {
+		t.Fatalf("downstream got %q, want %q", got, data)
+	}
+}
+
+func TestChaosWriterSlowDripWritesAllBytesInChunks(t *testing.T) {
+	rec := newRecorder()
+	cw := &chaosWriter{ResponseWriter: rec, profile: &Profile{SlowDripBytesPerSec: 100}}
+
+	data := []byte("0123456789abcdefghij") // 20 bytes, 10 bytes/chunk at this rate
+	n, err := cw.writeSlow(data)
+	if err != nil {
+		t.Fatalf("writeSlow: %v", err)
+	}
+	if n != len(data) {
+		t.Fatalf("writeSlow returned n = %d, want %d", n, len(data))
+	}
+	if got := rec.buf.String(); got != string(data) {
+		t.Fatalf("downstream got %q, want the full body %q", got, data)
+	}
+}
+
+func TestProfileLatencyFixedWithoutStdDev(t *testing.T) {
+	p := Profile{LatencyMeanMs: 25}
+	if got := p.latency(); got.Milliseconds() != 25 {
+		t.Fatalf("latency() = %v, want 25ms", got)
+	}
+}
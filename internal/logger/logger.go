@@ -0,0 +1,87 @@
+// Package logger provides the pluggable request logger used by the
+// server's logging middleware, with a human-readable text mode and a
+// structured JSON mode for shipping to log aggregators.
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+)
+
+// Entry is one logged request.
+type Entry struct {
+	RequestID  string
+	Method     string
+	Path       string
+	Remote     string
+	Status     int
+	BytesOut   int
+	DurationMs float64
+	Rule       string
+}
+
+// Logger records one Entry per request.
+type Logger interface {
+	Log(Entry)
+}
+
+// Text logs entries as one space-separated line (request ID, method, path,
+// remote, status, bytes, duration, rule) via the standard log package (so it still respects *log.Logger's
+// prefix/flags and ErrorLog wiring).
+type Text struct {
+	std *log.Logger
+}
+
+// NewText wraps std as a Logger.
+func NewText(std *log.Logger) *Text {
+	return &Text{std: std}
+}
+
+func (t *Text) Log(e Entry) {
+	rule := e.Rule
+	if rule == "" {
+		rule = "-"
+	}
+	t.std.Printf("%s %s %s %s %d %dB %.1fms rule=%s\n",
+		e.RequestID, e.Method, e.Path, e.Remote, e.Status, e.BytesOut, e.DurationMs, rule)
+}
+
+// JSON logs one JSON object per request to w.
+type JSON struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSON wraps w as a Logger.
+func NewJSON(w io.Writer) *JSON {
+	return &JSON{w: w}
+}
+
+func (j *JSON) Log(e Entry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_ = json.NewEncoder(j.w).Encode(jsonEntry{
+		RequestID:  e.RequestID,
+		Method:     e.Method,
+		Path:       e.Path,
+		Remote:     e.Remote,
+		Status:     e.Status,
+		BytesOut:   e.BytesOut,
+		DurationMs: e.DurationMs,
+		Rule:       e.Rule,
+	})
+}
+
+type jsonEntry struct {
+	RequestID  string  `json:"request_id"`
+	Method     string  `json:"method"`
+	Path       string  `json:"path"`
+	Remote     string  `json:"remote"`
+	Status     int     `json:"status"`
+	BytesOut   int     `json:"bytes_out"`
+	DurationMs float64 `json:"duration_ms"`
+	Rule       string  `json:"rule,omitempty"`
+}
@@ -0,0 +1,67 @@
+// Package metrics exposes the server's Prometheus instrumentation:
+// request counts and latency by path/status, plus gauges for overall
+// health and the last fixture/template reload.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "padt_requests_total",
+		Help: "Total number of requests served, by path and response status.",
+	}, []string{"path", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "padt_request_duration_seconds",
+		Help:    "Request handling duration in seconds, by path.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"path"})
+
+	healthy = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "padt_healthy",
+		Help: "1 if the server is healthy and accepting requests, 0 otherwise.",
+	})
+
+	templateReloadTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "padt_template_reload_timestamp",
+		Help: "Unix timestamp of the most recent fixture/template reload.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(requestsTotal, requestDuration, healthy, templateReloadTimestamp)
+}
+
+// Handler serves the /metrics endpoint.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRequest records one completed request against the counters and
+// histogram.
+func ObserveRequest(path string, status int, duration time.Duration) {
+	requestsTotal.WithLabelValues(path, strconv.Itoa(status)).Inc()
+	requestDuration.WithLabelValues(path).Observe(duration.Seconds())
+}
+
+// SetHealthy updates the padt_healthy gauge.
+func SetHealthy(h bool) {
+	if h {
+		healthy.Set(1)
+		return
+	}
+	healthy.Set(0)
+}
+
+// SetTemplateReloadTimestamp updates the padt_template_reload_timestamp
+// gauge to t.
+func SetTemplateReloadTimestamp(t time.Time) {
+	templateReloadTimestamp.Set(float64(t.Unix()))
+}
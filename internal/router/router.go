@@ -0,0 +1,145 @@
+// Package router evaluates an ordered list of route rules against an
+// incoming request so a single server instance can mock the many PADT
+// operations (ManageParty, GetParty, ...) and their different
+// success/error scenarios from one config file instead of one fixture
+// per process.
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/CaleMacDonald/padt_api_server/internal/tmpl"
+	"gopkg.in/yaml.v3"
+)
+
+// Matcher describes the conditions a request must satisfy for its rule to
+// apply. A zero-value field is treated as "don't care". All non-zero
+// fields must match (logical AND).
+type Matcher struct {
+	Method      string            `yaml:"method"`
+	Path        string            `yaml:"path"`
+	HeaderRegex map[string]string `yaml:"headers"`
+	BodyXPath   *FieldPredicate   `yaml:"bodyXPath"`
+	BodyJSON    *FieldPredicate   `yaml:"bodyJSON"`
+
+	headerRegex map[string]*regexp.Regexp
+}
+
+// FieldPredicate matches a single extracted value (via XPath or a dotted
+// JSON path) against an expected value.
+type FieldPredicate struct {
+	Path   string `yaml:"path"`
+	Equals string `yaml:"equals"`
+}
+
+// ResponseSpec describes the fixture to serve when a rule matches.
+type ResponseSpec struct {
+	Status  int               `yaml:"status"`
+	Headers map[string]string `yaml:"headers"`
+	File    string            `yaml:"file"`
+	Delay   time.Duration     `yaml:"delay"`
+}
+
+// RouteRule pairs a Matcher with the ResponseSpec to serve when it matches.
+type RouteRule struct {
+	Name     string       `yaml:"name"`
+	Match    Matcher      `yaml:"match"`
+	Response ResponseSpec `yaml:"response"`
+}
+
+type config struct {
+	Rules []RouteRule `yaml:"rules"`
+}
+
+// LoadConfig reads and parses a route config YAML file.
+func LoadConfig(src []byte) ([]RouteRule, error) {
+	var cfg config
+	if err := yaml.Unmarshal(src, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing route config: %w", err)
+	}
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].Match.compile(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", cfg.Rules[i].Name, err)
+		}
+	}
+	return cfg.Rules, nil
+}
+
+func (m *Matcher) compile() error {
+	if len(m.HeaderRegex) == 0 {
+		return nil
+	}
+	m.headerRegex = make(map[string]*regexp.Regexp, len(m.HeaderRegex))
+	for name, pattern := range m.HeaderRegex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("header %q: %w", name, err)
+		}
+		m.headerRegex[name] = re
+	}
+	return nil
+}
+
+// Matches reports whether r (with its already-read body) satisfies m.
+func (m Matcher) Matches(r *http.Request, body []byte) bool {
+	if m.Method != "" && !strings.EqualFold(m.Method, r.Method) {
+		return false
+	}
+	if m.Path != "" {
+		ok, err := path.Match(m.Path, r.URL.Path)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	for name, re := range m.headerRegex {
+		if !re.MatchString(r.Header.Get(name)) {
+			return false
+		}
+	}
+	if m.BodyXPath != nil && tmpl.XPath(body, m.BodyXPath.Path) != m.BodyXPath.Equals {
+		return false
+	}
+	if m.BodyJSON != nil && !jsonFieldEquals(body, m.BodyJSON.Path, m.BodyJSON.Equals) {
+		return false
+	}
+	return true
+}
+
+// jsonFieldEquals reports whether the dotted field path in the JSON
+// document body equals want. Non-JSON bodies or absent fields never
+// match, matching the forgiving behaviour of tmpl.XPath.
+func jsonFieldEquals(body []byte, field, want string) bool {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return false
+	}
+	for _, part := range strings.Split(field, ".") {
+		m, ok := doc.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		doc, ok = m[part]
+		if !ok {
+			return false
+		}
+	}
+	return fmt.Sprintf("%v", doc) == want
+}
+
+// Select returns the first rule in rules whose Matcher matches r, or nil
+// if none do. Rules are evaluated in order, so more specific rules should
+// be listed before general fallbacks.
+func Select(rules []RouteRule, r *http.Request, body []byte) *RouteRule {
+	for i := range rules {
+		if rules[i].Match.Matches(r, body) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
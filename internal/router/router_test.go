@@ -0,0 +1,136 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMatcherMatchesPathGlob(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"exact match", "/padt", "/padt", true},
+		{"exact mismatch", "/padt", "/other", false},
+		{"single segment wildcard", "/padt/*", "/padt/accounts", true},
+		{"wildcard does not cross segments", "/padt/*", "/padt/accounts/1", false},
+		{"empty pattern matches anything", "", "/whatever", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Matcher{Path: tt.pattern}
+			if err := m.compile(); err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if got := m.Matches(req, nil); got != tt.want {
+				t.Fatalf("Matches(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherCombinesPredicatesWithAND(t *testing.T) {
+	m := Matcher{
+		Method:      http.MethodPost,
+		Path:        "/padt",
+		HeaderRegex: map[string]string{"X-Scenario": "^error-.*$"},
+	}
+	if err := m.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/padt", nil)
+	req.Header.Set("X-Scenario", "error-timeout")
+	if !m.Matches(req, nil) {
+		t.Fatalf("expected match when method, path and header all satisfy the matcher")
+	}
+
+	req.Header.Set("X-Scenario", "success")
+	if m.Matches(req, nil) {
+		t.Fatalf("expected no match once the header predicate fails, even though method and path still satisfy the matcher")
+	}
+
+	wrongMethod := httptest.NewRequest(http.MethodGet, "/padt", nil)
+	wrongMethod.Header.Set("X-Scenario", "error-timeout")
+	if m.Matches(wrongMethod, nil) {
+		t.Fatalf("expected no match once the method predicate fails, even though path and header still satisfy the matcher")
+	}
+}
+
+func TestJSONFieldEquals(t *testing.T) {
+	body := []byte(`{"party":{"type":"individual"},"count":3}`)
+
+	tests := []struct {
+		name  string
+		field string
+		want  string
+		match bool
+	}{
+		{"nested field matches", "party.type", "individual", true},
+		{"nested field mismatch", "party.type", "organization", false},
+		{"non-string field stringified", "count", "3", true},
+		{"missing leaf", "party.missing", "individual", false},
+		{"missing intermediate", "nope.type", "individual", false},
+		{"field path through a non-object value", "count.sub", "3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonFieldEquals(body, tt.field, tt.want); got != tt.match {
+				t.Fatalf("jsonFieldEquals(%q, %q) = %v, want %v", tt.field, tt.want, got, tt.match)
+			}
+		})
+	}
+
+	if jsonFieldEquals([]byte("not json"), "party.type", "individual") {
+		t.Fatalf("expected non-JSON body to never match")
+	}
+}
+
+func TestSelectReturnsFirstMatchingRuleInOrder(t *testing.T) {
+	rules := []RouteRule{
+		{Name: "specific", Match: Matcher{Path: "/padt", HeaderRegex: map[string]string{"X-Scenario": "^error$"}}},
+		{Name: "fallback", Match: Matcher{Path: "/padt"}},
+	}
+	for i := range rules {
+		if err := rules[i].Match.compile(); err != nil {
+			t.Fatalf("compile: %v", err)
+		}
+	}
+
+	errReq := httptest.NewRequest(http.MethodPost, "/padt", nil)
+	errReq.Header.Set("X-Scenario", "error")
+	if got := Select(rules, errReq, nil); got == nil || got.Name != "specific" {
+		t.Fatalf("Select() = %v, want the specific rule", got)
+	}
+
+	plainReq := httptest.NewRequest(http.MethodPost, "/padt", nil)
+	if got := Select(rules, plainReq, nil); got == nil || got.Name != "fallback" {
+		t.Fatalf("Select() = %v, want the fallback rule", got)
+	}
+
+	noMatch := httptest.NewRequest(http.MethodPost, "/elsewhere", nil)
+	if got := Select(rules, noMatch, nil); got != nil {
+		t.Fatalf("Select() = %v, want nil when no rule matches", got)
+	}
+}
+
+func TestLoadConfigRejectsInvalidHeaderRegex(t *testing.T) {
+	src := []byte(strings.Join([]string{
+		"rules:",
+		"  - name: bad",
+		"    match:",
+		"      headers:",
+		"        X-Scenario: \"(\"",
+	}, "\n"))
+
+	if _, err := LoadConfig(src); err == nil {
+		t.Fatalf("expected an error for an invalid header regex")
+	}
+}
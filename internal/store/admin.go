@@ -0,0 +1,49 @@
+package store
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ReloadHandler serves POST /admin/reload: it forces every cached fixture
+// to re-read its file immediately, rather than waiting on the watcher.
+func ReloadHandler(reg *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		errs := reg.ReloadAll()
+		resp := struct {
+			Reloaded int               `json:"reloaded"`
+			Errors   map[string]string `json:"errors,omitempty"`
+		}{}
+		resp.Reloaded = len(reg.Files()) - len(errs)
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(errs) > 0 {
+			resp.Errors = make(map[string]string, len(errs))
+			for path, err := range errs {
+				resp.Errors[path] = err.Error()
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// FilesHandler serves GET /admin/files: a snapshot of every fixture
+// currently held in the cache, for debugging what the server would serve.
+func FilesHandler(reg *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(reg.Files())
+	})
+}
@@ -0,0 +1,213 @@
+// Package store serves response fixture files from an in-memory cache
+// that is refreshed by a filesystem watcher, so concurrent requests never
+// race on the file handle and edits to a fixture on disk take effect
+// without restarting the server.
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/CaleMacDonald/padt_api_server/internal/metrics"
+	"github.com/CaleMacDonald/padt_api_server/internal/tmpl"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Entry is the immutable snapshot of a fixture file held by a Store. A
+// new Entry replaces the old one wholesale on every reload, so readers
+// never observe a partially-updated file.
+type Entry struct {
+	ModTime  time.Time
+	Raw      []byte
+	Template *template.Template
+	Err      error
+}
+
+// Store holds the current Entry for one fixture file behind an
+// atomic.Value, plus the fsnotify watcher that keeps it fresh.
+type Store struct {
+	path       string
+	isTemplate bool
+	value      atomic.Value // *Entry
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewStore loads path immediately and starts watching its directory for
+// changes. isTemplate controls whether the contents are also parsed as a
+// Go text/template on each (re)load.
+func NewStore(path string, isTemplate bool) (*Store, error) {
+	s := &Store{path: path, isTemplate: isTemplate}
+	s.reload()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	s.watcher = watcher
+	s.done = make(chan struct{})
+	go s.watchLoop()
+
+	return s, nil
+}
+
+func (s *Store) watchLoop() {
+	target := filepath.Clean(s.path)
+	for {
+		select {
+		case ev, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) != target {
+				continue
+			}
+			if ev.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				s.reload()
+				metrics.SetTemplateReloadTimestamp(time.Now())
+			}
+		case _, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// reload re-reads the file from disk and atomically swaps in a new Entry.
+func (s *Store) reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		s.value.Store(&Entry{Err: err})
+		return err
+	}
+
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		s.value.Store(&Entry{Err: err})
+		return err
+	}
+
+	entry := &Entry{ModTime: info.ModTime(), Raw: raw}
+	if s.isTemplate {
+		// The template functions must be known at parse time; renderTemplate
+		// rebinds them to the actual request on a clone before executing.
+		t, err := template.New(filepath.Base(s.path)).Funcs(tmpl.FuncMap(nil, nil)).Parse(string(raw))
+		if err != nil {
+			entry = &Entry{Err: err}
+		} else {
+			entry.Template = t
+		}
+	}
+
+	s.value.Store(entry)
+	return entry.Err
+}
+
+// Reload forces an immediate re-read, bypassing the watcher. It returns
+// whatever error the reload produced (also visible via Get's Entry.Err).
+func (s *Store) Reload() error {
+	return s.reload()
+}
+
+// Get returns the current Entry. It never blocks on I/O: reads always
+// come from the last successful (or failed) reload.
+func (s *Store) Get() *Entry {
+	return s.value.Load().(*Entry)
+}
+
+// Path returns the fixture file path this Store serves.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Close stops the watcher goroutine and releases its file descriptors.
+func (s *Store) Close() error {
+	close(s.done)
+	return s.watcher.Close()
+}
+
+// Registry lazily creates and memoizes a Store per file path, so the
+// dispatcher can share one watched, cached copy of a fixture across every
+// route rule that references it.
+type Registry struct {
+	mu     sync.Mutex
+	stores map[string]*Store
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{stores: make(map[string]*Store)}
+}
+
+// Get returns the Store for path, creating and starting it on first use.
+func (r *Registry) Get(path string, isTemplate bool) (*Store, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, ok := r.stores[path]; ok {
+		return s, nil
+	}
+	s, err := NewStore(path, isTemplate)
+	if err != nil {
+		return nil, err
+	}
+	r.stores[path] = s
+	return s, nil
+}
+
+// ReloadAll forces every known Store to re-read its file, returning the
+// errors (if any) keyed by path.
+func (r *Registry) ReloadAll() map[string]error {
+	r.mu.Lock()
+	stores := make([]*Store, 0, len(r.stores))
+	for _, s := range r.stores {
+		stores = append(stores, s)
+	}
+	r.mu.Unlock()
+
+	errs := make(map[string]error)
+	for _, s := range stores {
+		if err := s.Reload(); err != nil {
+			errs[s.Path()] = err
+		}
+	}
+	return errs
+}
+
+// FileInfo summarizes one cached fixture for the admin inspection endpoint.
+type FileInfo struct {
+	Path     string    `json:"path"`
+	ModTime  time.Time `json:"modTime"`
+	Bytes    int       `json:"bytes"`
+	Template bool      `json:"template"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Files returns a snapshot of every fixture currently cached.
+func (r *Registry) Files() []FileInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	infos := make([]FileInfo, 0, len(r.stores))
+	for path, s := range r.stores {
+		entry := s.Get()
+		info := FileInfo{Path: path, ModTime: entry.ModTime, Bytes: len(entry.Raw), Template: s.isTemplate}
+		if entry.Err != nil {
+			info.Error = entry.Err.Error()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestStoreConcurrentReadsUnderReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fixture.xml")
+	if err := os.WriteFile(path, []byte("v0"), 0o644); err != nil {
+		t.Fatalf("seeding fixture: %v", err)
+	}
+
+	s, err := NewStore(path, false)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer s.Close()
+
+	const rounds = 50
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			if entry := s.Get(); entry.Err != nil {
+				t.Errorf("unexpected read error: %v", entry.Err)
+			}
+		}
+	}()
+
+	for i := 1; i <= rounds; i++ {
+		content := "v" + strconv.Itoa(i)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("writing fixture: %v", err)
+		}
+		if err := s.Reload(); err != nil {
+			t.Fatalf("Reload: %v", err)
+		}
+	}
+
+	wg.Wait()
+
+	if got := string(s.Get().Raw); got != "v"+strconv.Itoa(rounds) {
+		t.Fatalf("final contents = %q, want %q", got, "v"+strconv.Itoa(rounds))
+	}
+}
+
+func TestStoreMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "missing.xml")
+
+	s, err := NewStore(path, false)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer s.Close()
+
+	if entry := s.Get(); entry.Err == nil {
+		t.Fatalf("expected error for missing file, got none")
+	}
+}
+
+func TestRegistryReloadAll(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fixture.xml")
+	if err := os.WriteFile(path, []byte("v0"), 0o644); err != nil {
+		t.Fatalf("seeding fixture: %v", err)
+	}
+
+	reg := NewRegistry()
+	s, err := reg.Get(path, false)
+	if err != nil {
+		t.Fatalf("Registry.Get: %v", err)
+	}
+	defer s.Close()
+
+	if err := os.WriteFile(path, []byte("v1"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+	if errs := reg.ReloadAll(); len(errs) != 0 {
+		t.Fatalf("ReloadAll returned errors: %v", errs)
+	}
+
+	if got := string(s.Get().Raw); got != "v1" {
+		t.Fatalf("contents after ReloadAll = %q, want %q", got, "v1")
+	}
+
+	files := reg.Files()
+	if len(files) != 1 || files[0].Path != path {
+		t.Fatalf("Files() = %v, want single entry for %q", files, path)
+	}
+}
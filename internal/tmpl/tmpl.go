@@ -0,0 +1,101 @@
+// Package tmpl provides the Go text/template function registry used by
+// the PADT mock server to render response fixtures that reference data
+// from the incoming request. Parsing and caching of the templates
+// themselves lives in internal/store.
+package tmpl
+
+import (
+	"encoding/xml"
+	"math/rand"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Context is the value exposed to a response template as the root ".".
+// It bundles the parts of the incoming request a fixture author might
+// want to echo back: headers, query params and the raw request body.
+type Context struct {
+	Header http.Header
+	Query  map[string][]string
+	Body   []byte
+}
+
+// FuncMap returns the set of functions available to response templates.
+// xpath and header close over the request so fixtures can pull values
+// straight out of it, e.g. {{ xpath "EventCorrelationId" }}.
+func FuncMap(r *http.Request, body []byte) template.FuncMap {
+	return template.FuncMap{
+		"uuid":       newUUID,
+		"now":        func() string { return time.Now().Format(time.RFC3339Nano) },
+		"nowUTC":     func() string { return time.Now().UTC().Format(time.RFC3339Nano) },
+		"header":     func(name string) string { return r.Header.Get(name) },
+		"xpath":      func(path string) string { return XPath(body, path) },
+		"randInt":    randInt,
+		"randString": randString,
+	}
+}
+
+func newUUID() string {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+// randInt returns a pseudo-random integer in [min, max).
+func randInt(min, max int) int {
+	if max <= min {
+		return min
+	}
+	return min + rand.Intn(max-min)
+}
+
+const randStringAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// randString returns a pseudo-random alphanumeric string of length n.
+func randString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randStringAlphabet[rand.Intn(len(randStringAlphabet))]
+	}
+	return string(b)
+}
+
+// XPath walks body as XML looking for the first element whose local name
+// matches the last segment of path (a leading "/" or "//" is accepted but
+// not otherwise treated as a full XPath expression) and returns its
+// character data. It is deliberately forgiving: malformed or non-XML
+// bodies simply yield no match rather than an error, since both response
+// templates and route matchers are meant to degrade gracefully when a
+// field is absent.
+func XPath(body []byte, path string) string {
+	name := path
+	if idx := strings.LastIndex(name, "/"); idx != -1 {
+		name = name[idx+1:]
+	}
+	if name == "" {
+		return ""
+	}
+
+	dec := xml.NewDecoder(strings.NewReader(string(body)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != name {
+			continue
+		}
+		var text string
+		if err := dec.DecodeElement(&text, &start); err != nil {
+			return ""
+		}
+		return strings.TrimSpace(text)
+	}
+}
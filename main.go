@@ -1,48 +1,141 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
+	"github.com/CaleMacDonald/padt_api_server/internal/chaos"
+	"github.com/CaleMacDonald/padt_api_server/internal/logger"
+	"github.com/CaleMacDonald/padt_api_server/internal/metrics"
+	"github.com/CaleMacDonald/padt_api_server/internal/router"
+	"github.com/CaleMacDonald/padt_api_server/internal/store"
+	"github.com/CaleMacDonald/padt_api_server/internal/tmpl"
 	"github.com/google/uuid"
+	"golang.org/x/crypto/acme/autocert"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"sync/atomic"
+	"text/template"
 	"time"
 )
 
 type key int
 
 const (
-	requestIDKey key = 0
+	requestIDKey key = iota
+	ruleNameKey
 )
 
 var (
-	listenAddr string
-	healthy    int32
-	debug      bool
-	file       string
+	listenAddr   string
+	healthy      int32
+	debug        bool
+	file         string
+	configPath   string
+	useTemplate  bool
+	fileRegistry = store.NewRegistry()
+
+	chaosEnabled   bool
+	chaosLatencyMs int
+	chaosErrorRate float64
+
+	logFormat string
+
+	tlsCert     string
+	tlsKey      string
+	clientCA    string
+	acmeDomains string
+	acmeCache   string
 )
 
+// templateExtensions are the file extensions that mark a response file as
+// a Go template even when -template wasn't passed explicitly.
+var templateExtensions = []string{".tmpl", ".gotmpl", ".tpl"}
+
+func isTemplateFile(path string) bool {
+	if useTemplate {
+		return true
+	}
+	for _, ext := range templateExtensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
 	flag.StringVar(&listenAddr, "listen-addr", ":5000", "server listen address")
 	flag.StringVar(&file, "file", "padt_response_file.xml", "The file to read")
 	flag.BoolVar(&debug, "debug", false, "Include logging of request details")
+	flag.BoolVar(&useTemplate, "template", false, "Treat the response file as a Go text/template (auto-detected for .tmpl/.gotmpl/.tpl files)")
+	flag.StringVar(&configPath, "config", "", "YAML file of route rules (and an optional chaos: section) to match requests against; replaces -file and the -chaos* flags, while -template still applies to every rule's file")
+	flag.BoolVar(&chaosEnabled, "chaos", false, "Enable chaos middleware with a profile built from the -chaos-* flags")
+	flag.IntVar(&chaosLatencyMs, "chaos-latency-ms", 0, "Fixed latency injected into every request when -chaos is set")
+	flag.Float64Var(&chaosErrorRate, "chaos-error-rate", 0, "Probability (0-1) of injecting a 5xx response when -chaos is set")
+	flag.StringVar(&logFormat, "log-format", "text", "Request log format: text or json")
+	flag.StringVar(&tlsCert, "tls-cert", "", "TLS certificate file; serves HTTPS instead of plaintext HTTP when set with -tls-key")
+	flag.StringVar(&tlsKey, "tls-key", "", "TLS private key file")
+	flag.StringVar(&clientCA, "client-ca", "", "PEM file of CA certificates to verify client certs against, enabling mTLS")
+	flag.StringVar(&acmeDomains, "acme-domains", "", "Comma-separated domains to request Let's Encrypt certificates for via ACME; overrides -tls-cert/-tls-key")
+	flag.StringVar(&acmeCache, "acme-cache", "acme-cache", "Directory autocert uses to cache ACME account keys and certificates")
 	flag.Parse()
 
-	logger := log.New(os.Stdout, "http: ", log.LstdFlags)
-	logger.Printf("Serving file %s\n", file)
-	logger.Println("Server is starting...")
+	stdLogger := log.New(os.Stdout, "http: ", log.LstdFlags)
+
+	if (tlsCert != "") != (tlsKey != "") {
+		stdLogger.Fatalf("-tls-cert and -tls-key must both be set, or neither\n")
+	}
+	if clientCA != "" && (tlsCert == "" || tlsKey == "") {
+		stdLogger.Fatalf("-client-ca requires both -tls-cert and -tls-key to be set\n")
+	}
 
-	router := http.NewServeMux()
-	router.Handle("/", index())
-	router.Handle("/healthz", healthz())
-	router.Handle("/padt", sendPadtResponse())
+	var lg logger.Logger
+	switch logFormat {
+	case "json":
+		lg = logger.NewJSON(os.Stdout)
+	case "text":
+		lg = logger.NewText(stdLogger)
+	default:
+		stdLogger.Fatalf("Unknown -log-format %q (want text or json)\n", logFormat)
+	}
+
+	rules, err := loadRouteRules()
+	if err != nil {
+		stdLogger.Fatalf("Could not load route rules: %v\n", err)
+	}
+	if configPath != "" {
+		stdLogger.Printf("Serving %d route rule(s) from %s\n", len(rules), configPath)
+	} else {
+		stdLogger.Printf("Serving file %s\n", file)
+	}
+	stdLogger.Println("Server is starting...")
+	metrics.SetTemplateReloadTimestamp(time.Now())
+
+	chaosCfg, err := loadChaosConfig()
+	if err != nil {
+		stdLogger.Fatalf("Could not load chaos config: %v\n", err)
+	}
+	chaosCtrl := chaos.NewController(chaosCfg)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", index())
+	mux.Handle("/healthz", healthz())
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/padt", chaos.Middleware(chaosCtrl)(dispatch(rules)))
+	mux.Handle("/chaos", chaos.AdminHandler(chaosCtrl))
+	mux.Handle("/chaos/scenarios/", chaos.ScenarioHandler(chaosCtrl))
+	mux.Handle("/admin/reload", reloadAndRecord(fileRegistry))
+	mux.Handle("/admin/files", store.FilesHandler(fileRegistry))
 
 	nextRequestID := func() string {
 		return fmt.Sprintf("%d", time.Now().UnixNano())
@@ -50,40 +143,109 @@ func main() {
 
 	server := &http.Server{
 		Addr:         listenAddr,
-		Handler:      tracing(nextRequestID)(logging(logger)(router)),
-		ErrorLog:     logger,
+		Handler:      tracing(nextRequestID)(logging(lg)(mux)),
+		ErrorLog:     stdLogger,
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  15 * time.Second,
 	}
 
+	var acmeManager *autocert.Manager
+	if acmeDomains != "" {
+		acmeManager = &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      autocert.DirCache(acmeCache),
+			HostPolicy: autocert.HostWhitelist(strings.Split(acmeDomains, ",")...),
+		}
+		server.TLSConfig = acmeManager.TLSConfig()
+	} else if tlsCert != "" || tlsKey != "" {
+		tlsConfig, err := buildTLSConfig()
+		if err != nil {
+			stdLogger.Fatalf("Could not configure TLS: %v\n", err)
+		}
+		server.TLSConfig = tlsConfig
+	}
+
 	done := make(chan bool)
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 
 	go func() {
 		<-quit
-		logger.Println("Server is shutting down...")
+		stdLogger.Println("Server is shutting down...")
 		atomic.StoreInt32(&healthy, 0)
+		metrics.SetHealthy(false)
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		server.SetKeepAlivesEnabled(false)
 		if err := server.Shutdown(ctx); err != nil {
-			logger.Fatalf("Could not gracefully shutdown the server: %v\n", err)
+			stdLogger.Fatalf("Could not gracefully shutdown the server: %v\n", err)
 		}
 		close(done)
 	}()
 
-	logger.Println("Server is ready to handle requests at", listenAddr)
+	stdLogger.Println("Server is ready to handle requests at", listenAddr)
 	atomic.StoreInt32(&healthy, 1)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		logger.Fatalf("Could not listen on %s: %v\n", listenAddr, err)
+	metrics.SetHealthy(true)
+
+	var serveErr error
+	switch {
+	case acmeManager != nil:
+		go func() {
+			if err := http.ListenAndServe(":80", acmeManager.HTTPHandler(nil)); err != nil {
+				stdLogger.Printf("ACME HTTP-01 challenge listener on :80 stopped: %v\n", err)
+			}
+		}()
+		serveErr = server.ListenAndServeTLS("", "")
+	case tlsCert != "" && tlsKey != "":
+		serveErr = server.ListenAndServeTLS(tlsCert, tlsKey)
+	default:
+		serveErr = server.ListenAndServe()
+	}
+	if serveErr != nil && serveErr != http.ErrServerClosed {
+		stdLogger.Fatalf("Could not listen on %s: %v\n", listenAddr, serveErr)
 	}
 
 	<-done
-	logger.Println("Server stopped")
+	stdLogger.Println("Server stopped")
+}
+
+// buildTLSConfig assembles the server's tls.Config from -tls-cert/-tls-key
+// and, when -client-ca is set, configures mutual TLS by requiring and
+// verifying client certificates against that trust bundle.
+func buildTLSConfig() (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if clientCA == "" {
+		return cfg, nil
+	}
+
+	pem, err := ioutil.ReadFile(clientCA)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", clientCA, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", clientCA)
+	}
+
+	cfg.ClientCAs = pool
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	return cfg, nil
+}
+
+// reloadAndRecord wraps store.ReloadHandler so a manual reload also bumps
+// the padt_template_reload_timestamp gauge.
+func reloadAndRecord(reg *store.Registry) http.Handler {
+	inner := store.ReloadHandler(reg)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner.ServeHTTP(w, r)
+		if r.Method == http.MethodPost {
+			metrics.SetTemplateReloadTimestamp(time.Now())
+		}
+	})
 }
 
 func index() http.Handler {
@@ -109,62 +271,220 @@ func healthz() http.Handler {
 	})
 }
 
-func sendPadtResponse() http.Handler {
-	filePath := file
+// loadRouteRules builds the ordered list of route rules the dispatcher
+// evaluates. With -config set, rules come from that YAML file; otherwise
+// a single catch-all rule is synthesized from -file so the single-fixture
+// behaviour keeps working unchanged.
+func loadRouteRules() ([]router.RouteRule, error) {
+	if configPath == "" {
+		return []router.RouteRule{{
+			Name:     "default",
+			Response: router.ResponseSpec{Status: http.StatusOK, File: file},
+		}}, nil
+	}
+
+	src, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", configPath, err)
+	}
+	rules, err := router.LoadConfig(src)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// loadChaosConfig builds the chaos.Config to start the server with. With
+// -config set, the "chaos:" section of that same route-rule file is used;
+// otherwise a single profile is synthesized from the -chaos-* flags.
+func loadChaosConfig() (chaos.Config, error) {
+	if configPath == "" {
+		return chaos.Config{
+			Enabled: chaosEnabled,
+			Default: chaos.Profile{
+				LatencyMeanMs: chaosLatencyMs,
+				ErrorRate:     chaosErrorRate,
+			},
+		}, nil
+	}
+
+	src, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return chaos.Config{}, fmt.Errorf("reading %s: %w", configPath, err)
+	}
+	return chaos.LoadConfig(src)
+}
+
+// dispatch matches each request against rules in order and serves the
+// first matching rule's response, rendering it as a Go template when the
+// file is one.
+func dispatch(rules []router.RouteRule) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		file, err := ioutil.ReadFile(file)
-		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			fmt.Fprintf(w, "Unable to read %s\nServing default response\n", filePath)
-			file = []byte(getDefaultResponse())
-		}
+		body, _ := ioutil.ReadAll(r.Body)
 
 		if debug {
 			fmt.Fprintln(os.Stdout, "--------------")
 			for k, v := range r.Header {
 				fmt.Fprintf(os.Stdout, "%q: %q\n", k, v)
 			}
-			body, _ := ioutil.ReadAll(r.Body)
 			fmt.Fprintf(os.Stdout, string(body))
 			fmt.Fprintln(os.Stdout, "--------------")
 		}
 
-		fileContent := string(file)
+		rule := router.Select(rules, r, body)
+		if rule == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, "No route rule matched this request")
+			return
+		}
+		if ruleName, ok := r.Context().Value(ruleNameKey).(*string); ok {
+			*ruleName = rule.Name
+		}
+
+		if rule.Response.Delay > 0 {
+			time.Sleep(rule.Response.Delay)
+		}
+
+		filePath := rule.Response.File
+		isTmpl := isTemplateFile(filePath)
 
-		partyId, err := uuid.NewRandom()
+		fs, err := fileRegistry.Get(filePath, isTmpl)
+		var entry *store.Entry
 		if err == nil {
-			fileContent = strings.ReplaceAll(fileContent, "${PartyID}", partyId.String())
+			entry = fs.Get()
+			err = entry.Err
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Unable to read %s: %v\n", filePath, err)
+			return
+		}
+
+		var fileContent string
+		if isTmpl {
+			fileContent, err = renderTemplate(entry.Template, r, body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(w, "Unable to render template %s: %v\n", filePath, err)
+				return
+			}
+		} else {
+			fileContent = string(entry.Raw)
+			partyId, uuidErr := uuid.NewRandom()
+			if uuidErr == nil {
+				fileContent = strings.ReplaceAll(fileContent, "${PartyID}", partyId.String())
+			}
 		}
 
-		w.Header().Set("Content-Type", "application/xml")
+		for name, value := range rule.Response.Headers {
+			w.Header().Set(name, value)
+		}
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/xml")
+		}
+		if rule.Response.Status != 0 {
+			w.WriteHeader(rule.Response.Status)
+		}
 		w.Write([]byte(fileContent))
 	})
 }
 
-func logging(logger *log.Logger) func(http.Handler) http.Handler {
+// renderTemplate executes the store-cached template t against a
+// tmpl.Context built from the incoming request. It clones t before
+// binding per-request funcs so concurrent requests sharing the same
+// cached template never race on its function map.
+func renderTemplate(t *template.Template, r *http.Request, body []byte) (string, error) {
+	t, err := t.Clone()
+	if err != nil {
+		return "", err
+	}
+	t = t.Funcs(tmpl.FuncMap(r, body))
+
+	ctx := tmpl.Context{
+		Header: r.Header,
+		Query:  r.URL.Query(),
+		Body:   body,
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, ctx); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// logging wraps next so every request is timed and logged through lg with
+// its outcome (status, bytes written, matched route rule), and its path,
+// status and duration are recorded in Prometheus regardless of which
+// handler actually served it.
+func logging(lg logger.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			defer func() {
-				requestID, ok := r.Context().Value(requestIDKey).(string)
-				if !ok {
-					requestID = "unknown"
-				}
-				logger.Println(requestID, r.Method, r.URL.Path, r.RemoteAddr, r.UserAgent())
-
-				buf := new(bytes.Buffer)
-				bytesRead, err := buf.ReadFrom(r.Body)
-				if err == nil && bytesRead > 0 {
-					logger.Println("-----------------")
-					logger.Println(buf.String())
-					logger.Println("-----------------")
-				}
-
-			}()
-			next.ServeHTTP(w, r)
+			start := time.Now()
+
+			ruleName := new(string)
+			ctx := context.WithValue(r.Context(), ruleNameKey, ruleName)
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			requestID, ok := r.Context().Value(requestIDKey).(string)
+			if !ok {
+				requestID = "unknown"
+			}
+
+			duration := time.Since(start)
+			lg.Log(logger.Entry{
+				RequestID:  requestID,
+				Method:     r.Method,
+				Path:       r.URL.Path,
+				Remote:     r.RemoteAddr,
+				Status:     rec.status,
+				BytesOut:   rec.bytes,
+				DurationMs: float64(duration) / float64(time.Millisecond),
+				Rule:       *ruleName,
+			})
+			metrics.ObserveRequest(r.URL.Path, rec.status, duration)
 		})
 	}
 }
 
+// statusRecorder wraps a ResponseWriter to capture the status code and
+// byte count actually sent to the client, since neither is otherwise
+// observable from outside the handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += n
+	return n, err
+}
+
+// Hijack and Flush pass through to the wrapped ResponseWriter so the chaos
+// middleware can still reset connections and flush slow-drip chunks.
+func (rec *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := rec.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hj.Hijack()
+}
+
+func (rec *statusRecorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 func tracing(nextRequestID func() string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -178,7 +498,3 @@ func tracing(nextRequestID func() string) func(http.Handler) http.Handler {
 		})
 	}
 }
-
-func getDefaultResponse() string {
-	return "<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?>\n<ManagePartyResponse xmlns=\"urn:inputoutput.wm.ms.com\" xmlns:ns6=\"urn:group.accounts.wm.ms.com\" xmlns:ns5=\"urn:user.wm.ms.com\" xmlns:ns8=\"urn:edd.wm.ms.com\" xmlns:ns7=\"urn:accounts.wm.ms.com\" xmlns:ns9=\"urn:partyext.wm.ms.com\" xmlns:ns11=\"urn:products.wm.ms.com\" xmlns:ns10=\"urn:operation.wm.ms.com\" xmlns:ns2=\"urn:basetypes.wm.ms.com\" xmlns:ns4=\"urn:Common.wm.ms.com\" xmlns:ns3=\"urn:party.wm.ms.com\">\n    <Parties>\n        <Party>\n            <ns9:Party>\n                <ns3:PartyId>\n                    <ns2:ID>3209601986633|9WW</ns2:ID>\n                    <ns2:IDType>\n                        <ns2:Code>CES</ns2:Code>\n                        <ns2:Value>CES</ns2:Value>\n                    </ns2:IDType>\n                </ns3:PartyId>\n                <ns3:PartyId>\n                    <ns2:ID>0607076334</ns2:ID>\n                    <ns2:IDType>\n                        <ns2:Code>PMH</ns2:Code>\n                    </ns2:IDType>\n                </ns3:PartyId>\n                <ns3:PartyType>\n                    <ns2:Code>I</ns2:Code>\n                    <ns2:Value>Individual</ns2:Value>\n                </ns3:PartyType>\n                <ns3:RelationshipType>\n                    <ns2:Code>P</ns2:Code>\n                    <ns2:Value>Prospect</ns2:Value>\n                </ns3:RelationshipType>\n                <ns3:NameInfo>\n                    <ns2:FirstName>LALA</ns2:FirstName>\n                    <ns2:LastName>SMITH</ns2:LastName>\n                    <ns2:IsInvalidName>false</ns2:IsInvalidName>\n                </ns3:NameInfo>\n                <ns3:Tax>\n                    <ns2:TaxType>\n                        <ns2:Code>S</ns2:Code>\n                        <ns2:Value>SSN</ns2:Value>\n                    </ns2:TaxType>\n                    <ns2:TaxId>765712215</ns2:TaxId>\n                    <ns2:IsInvalidTaxId>false</ns2:IsInvalidTaxId>\n                </ns3:Tax>\n                <ns3:Individual>\n                    <ns2:DateOfBirth>1983-04-22</ns2:DateOfBirth>\n                    <ns2:IsInvalidDateOfBirth>false</ns2:IsInvalidDateOfBirth>\n                </ns3:Individual>\n                <ns3:Contact>\n                    <ns2:ContactAddress>\n                        <ns2:Address TxType=\"C\">\n                            <ns2:AddressRowid>4140026</ns2:AddressRowid>\n                            <ns2:AddressRelRowid>1LGL</ns2:AddressRelRowid>\n                            <ns2:AddressId>A05001855978</ns2:AddressId>\n                            <ns2:AddressType>\n                                <ns2:Code>LGL</ns2:Code>\n                                <ns2:Value>Client Legal Address</ns2:Value>\n                            </ns2:AddressType>\n                            <ns2:StreetAddress1>Mysore Rd, Opp Bhel, Nayandanahalli</ns2:StreetAddress1>\n                            <ns2:City>Bangalore</ns2:City>\n                            <ns2:PostalCode>560039</ns2:PostalCode>\n                            <ns2:Country>\n                                <ns2:Code>IND</ns2:Code>\n                            </ns2:Country>\n                            <ns2:ForeignAddress>\n                                <ns2:Code>FRN</ns2:Code>\n                            </ns2:ForeignAddress>\n                            <ns2:IsValid>true</ns2:IsValid>\n                        </ns2:Address>\n                        <ns2:VanityAddress>\n                            <ns2:StreetAddress1>Mysore Rd, Opp Bhel, Nayandanahalli</ns2:StreetAddress1>\n                            <ns2:City>Bangalore</ns2:City>\n                            <ns2:Postal>560039</ns2:Postal>\n                            <ns2:Country>\n                                <ns2:Code>IND</ns2:Code>\n                                <ns2:Value>INDIA</ns2:Value>\n                            </ns2:Country>\n                        </ns2:VanityAddress>\n                    </ns2:ContactAddress>\n                    <ns2:Telephone TxType=\"C\">\n                        <ns2:TelephoneRowid>1740042</ns2:TelephoneRowid>\n                        <ns2:PhoneRelRowid>1CELL</ns2:PhoneRelRowid>\n                        <ns2:TelephoneId>T05000622214</ns2:TelephoneId>\n                        <ns2:PhoneNumber>+91-9872710627</ns2:PhoneNumber>\n                        <ns2:PhoneType>\n                            <ns2:Code>CELL</ns2:Code>\n                            <ns2:Value>Mobile Phone</ns2:Value>\n                        </ns2:PhoneType>\n                        <ns2:AuditData/>\n                    </ns2:Telephone>\n                    <ns2:ElectronicAddress TxType=\"C\">\n                        <ns2:ElectronicAddressRowid>1000071</ns2:ElectronicAddressRowid>\n                        <ns2:ElectronicRelRowid>1HOMEML</ns2:ElectronicRelRowid>\n                        <ns2:ElectronicAddressId>E05000384759</ns2:ElectronicAddressId>\n                        <ns2:ElectronicAddress>lala.smith@sso.com</ns2:ElectronicAddress>\n                        <ns2:ElectronicAddressMethod>\n                            <ns2:Code>EMAIL</ns2:Code>\n                            <ns2:Value>Email</ns2:Value>\n                        </ns2:ElectronicAddressMethod>\n                        <ns2:ElectronicAddressType>\n                            <ns2:Code>HOMEML</ns2:Code>\n                            <ns2:Value>Home Email</ns2:Value>\n                        </ns2:ElectronicAddressType>\n                    </ns2:ElectronicAddress>\n                </ns3:Contact>\n                <ns3:IsTestParty>false</ns3:IsTestParty>\n                <ns3:AdditionalInfo>\n                    <ns2:Code>EDBSync</ns2:Code>\n                    <ns2:Value>N</ns2:Value>\n                </ns3:AdditionalInfo>\n                <ns3:AdditionalInfo>\n                    <ns2:Code>MATCH_STA</ns2:Code>\n                    <ns2:Value>NOT-MATCHED</ns2:Value>\n                </ns3:AdditionalInfo>\n                <ns3:AdditionalInfo>\n                    <ns2:Code>CoreDataUpdate</ns2:Code>\n                    <ns2:Value>Yes</ns2:Value>\n                </ns3:AdditionalInfo>\n                <ns3:AdditionalInfo>\n                    <ns2:Code>Created</ns2:Code>\n                    <ns2:Value>Prospect</ns2:Value>\n                </ns3:AdditionalInfo>\n                <ns3:AdditionalInfo>\n                    <ns2:Code>isCodeTranslationRequired</ns2:Code>\n                    <ns2:Value>true</ns2:Value>\n                </ns3:AdditionalInfo>\n                <ns3:AdditionalInfo>\n                    <ns2:Code>APPTYPE</ns2:Code>\n                    <ns2:Value>00</ns2:Value>\n                </ns3:AdditionalInfo>\n                <ns3:ChannelType>\n                    <ns2:Code>PRODUCT_CODE</ns2:Code>\n                    <ns2:Value>SDB</ns2:Value>\n                </ns3:ChannelType>\n                <ns3:ChannelType>\n                    <ns2:Code>MSA</ns2:Code>\n                    <ns2:Value>Y</ns2:Value>\n                </ns3:ChannelType>\n                <ns3:PlanParticipation>\n                    <ns2:Identifier>\n                        <ns2:ID>9WW</ns2:ID>\n                        <ns2:IDType>CORP_ID</ns2:IDType>\n                    </ns2:Identifier>\n                    <ns2:FA/>\n                    <ns2:SourceApplicationCode>\n                        <ns2:Code>CS-SN</ns2:Code>\n                        <ns2:Value>Corporate Solution Solium Native</ns2:Value>\n                    </ns2:SourceApplicationCode>\n                </ns3:PlanParticipation>\n            </ns9:Party>\n        </Party>\n    </Parties>\n    <TransactionInfo>\n        <ns10:EventCorrelationId>4f04baaf-a355-4d4a-945e-54997f5c8594</ns10:EventCorrelationId>\n        <ns10:EventTimeStamp>2021-09-21T08:28:40.351-04:00</ns10:EventTimeStamp>\n        <ns10:EventSource>SHAREWORKS_IDP</ns10:EventSource>\n        <ns10:EventName>PROSPECT.ADD</ns10:EventName>\n        <ns10:TransactionActionType>MANAGE_PROSPECT Call from SHAREWORKS</ns10:TransactionActionType>\n        <ns10:TransactionSource>CES</ns10:TransactionSource>\n        <ns10:TransactionTimeStamp>2021-09-21T08:28:40.351-04:00</ns10:TransactionTimeStamp>\n        <ns10:TransactionUser>SUM_Shareworks</ns10:TransactionUser>\n        <ns10:TransactionProgram>SHAREWORKS_IDP</ns10:TransactionProgram>\n        <ns10:UseCaseNumber>IDP</ns10:UseCaseNumber>\n    </TransactionInfo>\n    <StatusInfo>\n        <ns10:Code>000</ns10:Code>\n        <ns10:TechnicalDescription>CreateParty Success</ns10:TechnicalDescription>\n        <ns10:BusinessDescription>SUCCESS</ns10:BusinessDescription>\n        <ns10:Retryable>false</ns10:Retryable>\n    </StatusInfo>\n</ManagePartyResponse>\n"
-}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CaleMacDonald/padt_api_server/internal/router"
+)
+
+// TestDispatchMissingFileStopsAfterError guards against a regression where
+// the file-read-error branch fell through into the header/status-setting
+// code below it: the client would see a 500 with the rule's configured
+// status and headers silently dropped, and the error text concatenated
+// with the (unwanted) default response body.
+func TestDispatchMissingFileStopsAfterError(t *testing.T) {
+	rules := []router.RouteRule{{
+		Name: "missing-fixture",
+		Response: router.ResponseSpec{
+			Status:  http.StatusCreated,
+			Headers: map[string]string{"X-Custom": "expected"},
+			File:    "/does/not/exist.xml",
+		},
+	}}
+
+	req := httptest.NewRequest(http.MethodPost, "/padt", strings.NewReader("<x/>"))
+	rec := httptest.NewRecorder()
+
+	dispatch(rules).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got := rec.Header().Get("X-Custom"); got != "" {
+		t.Fatalf("X-Custom header = %q, want unset", got)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "Unable to read") {
+		t.Fatalf("body = %q, want it to contain the read error", body)
+	}
+}